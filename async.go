@@ -16,18 +16,6 @@ type Carrier interface {
 	Set(key, value string)
 }
 
-// ContextPropagator is used for moving values from the ctx into the new context.
-// This is in order to preserve needed values between the context when initializing a new go routine.
-type ContextPropagator interface {
-	MoveToContext(from, to context.Context) context.Context
-}
-
-type ContextPropagatorFunc func(from, to context.Context) context.Context
-
-func (f ContextPropagatorFunc) MoveToContext(from, to context.Context) context.Context {
-	return f(from, to)
-}
-
 const (
 	defaultMaxGoRoutines       = 100
 	defaultTimeoutForGuard     = time.Second * 5
@@ -40,7 +28,12 @@ type Async struct {
 	reporter            ErrorReporter
 	timeoutForGuard     time.Duration
 	timeoutForGoRoutine time.Duration
-	contextPropagators  []ContextPropagator
+	contextInjectors    []Injector
+	retryPolicy         *RetryPolicy
+	middlewares         []Middleware
+	metrics             Metrics
+
+	svc *baseService
 }
 
 func New(options ...AsyncOption) *Async {
@@ -49,59 +42,125 @@ func New(options ...AsyncOption) *Async {
 		maxGoRoutines:       defaultMaxGoRoutines,
 		timeoutForGuard:     defaultTimeoutForGuard,
 		timeoutForGoRoutine: defaultTimeoutForGoRoutine,
+		metrics:             noopMetrics{},
 	}
 
 	for _, op := range options {
 		op(&conf)
 	}
 
-	return &Async{
+	a := &Async{
 		guard:               make(chan struct{}, conf.maxGoRoutines),
 		reporter:            conf.reporter,
 		timeoutForGuard:     conf.timeoutForGuard,
 		timeoutForGoRoutine: conf.timeoutForGoRoutine,
-		contextPropagators:  conf.contextPropagators,
+		contextInjectors:    conf.contextInjectors,
+		retryPolicy:         conf.retryPolicy,
+		middlewares:         conf.middlewares,
+		metrics:             conf.metrics,
+		svc:                 newBaseService(),
 	}
+	_ = a.svc.Start(context.Background())
+
+	return a
 }
 
 func (a *Async) RunAsync(ctx context.Context, fn HandleFunc) {
-	ctx = asyncContext(ctx, a.contextPropagators)
+	if !a.svc.Acquire() {
+		a.reporter.Error(ctx, ErrNotRunning)
+		return
+	}
+
+	ctx = a.asyncContext(ctx)
+
+	enqueuedAt := time.Now()
 
 	select {
 	case a.guard <- struct{}{}:
+		a.metrics.ObserveEnqueueLatency(time.Since(enqueuedAt))
 		go func() {
-			ctx, cacnelFunc := context.WithTimeout(ctx, a.timeoutForGoRoutine)
-
-			var err error
 			defer func() {
-				cacnelFunc()
 				<-a.guard
+				a.svc.Release()
 			}()
 
-			defer recoverPanic(ctx, a.reporter)
+			fn := applyMiddlewares(fn, a.middlewares)
 
-			if err = fn(ctx); err != nil {
+			if a.retryPolicy != nil {
+				if err := runWithRetry(ctx, *a.retryPolicy, a.timeoutForGoRoutine, a.metrics, fn); err != nil {
+					a.reporter.Error(ctx, fmt.Errorf("async func failed: %w", err))
+				}
+				return
+			}
+
+			ctx, cacnelFunc := context.WithTimeout(ctx, a.timeoutForGoRoutine)
+			defer cacnelFunc()
+			ctx = withAttempt(ctx, 1)
+
+			defer recoverPanic(ctx, a.reporter, a.metrics)
+
+			start := time.Now()
+			err := fn(ctx)
+			a.metrics.ObserveFuncDuration(time.Since(start), err)
+
+			if err != nil {
 				a.reporter.Error(ctx, fmt.Errorf("async func failed: %w", err))
 			}
 		}()
 
 	case <-time.After(a.timeoutForGuard):
+		a.svc.Release()
+		a.metrics.IncEnqueueTimeout()
 		a.reporter.Error(ctx, errorTimeout(fmt.Errorf("async timeout while waiting to guard")))
 	}
 }
 
-func asyncContext(ctx context.Context, contextPropagators []ContextPropagator) context.Context {
-	newCtx := context.Background()
+// Wait blocks until every dispatched HandleFunc accepted by RunAsync has finished running.
+func (a *Async) Wait() {
+	a.svc.Wait()
+}
+
+// Stop marks the Async as no longer accepting new work and waits for in-flight RunAsync calls to
+// finish, up to ctx's deadline. Stop is idempotent and safe for concurrent callers.
+func (a *Async) Stop(ctx context.Context) error {
+	var err error
+	a.svc.Stop(func() {
+		done := make(chan struct{})
+		go func() {
+			a.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = fmt.Errorf("async Stop: %w", ctx.Err())
+		}
+	})
+
+	return err
+}
+
+// IsRunning reports whether the Async is still accepting new work.
+func (a *Async) IsRunning() bool {
+	return a.svc.IsRunning()
+}
 
-	for _, propagator := range contextPropagators {
-		newCtx = propagator.MoveToContext(ctx, newCtx)
+// asyncContext builds the detached context a dispatched HandleFunc actually runs under: a fresh
+// context.Background(), with whatever values a.contextInjectors choose to carry over from ctx.
+func (a *Async) asyncContext(ctx context.Context) context.Context {
+	carrier := ctxCarrier{context.Background()}
+	for _, inj := range a.contextInjectors {
+		inj.Inject(ctx, &carrier)
 	}
 
-	return newCtx
+	return carrier.ctx
 }
 
-func recoverPanic(ctx context.Context, reporter ErrorReporter) {
+func recoverPanic(ctx context.Context, reporter ErrorReporter, metrics Metrics) {
 	if r := recover(); r != nil {
+		metrics.IncPanic()
+
 		err, ok := r.(error)
 		if !ok {
 			err = fmt.Errorf("%v", r)
@@ -107,11 +107,13 @@ func Test_async_options(t *testing.T) {
 
 type reporter struct {
 	called  bool
+	lastErr error
 	errorCh chan struct{}
 }
 
 func (r *reporter) Error(ctx context.Context, err error) {
 	r.called = true
+	r.lastErr = err
 	if r.errorCh != nil {
 		r.errorCh <- struct{}{}
 	}
@@ -120,7 +122,7 @@ func (r *reporter) Error(ctx context.Context, err error) {
 type injector struct {
 }
 
-func (i injector) Inject(ctx context.Context, carrier interface{ async.Carrier }) {
+func (i injector) Inject(ctx context.Context, carrier async.Carrier) {
 	val, ok := ctx.Value("someKey").(string)
 	if !ok {
 		return
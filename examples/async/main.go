@@ -10,10 +10,7 @@ import (
 
 func main() {
 	a := async.New(
-		async.WithContextPropagation(async.ContextPropagatorFunc(func(from, to context.Context) context.Context {
-			value := from.Value("SomeKey")
-			return context.WithValue(to, "SomeKey", value)
-		})),
+		async.WithContextInjector(someKeyInjector{}),
 	)
 
 	ctx, cancelFunc := context.WithCancel(context.Background())
@@ -40,4 +37,15 @@ func main() {
 	fmt.Println("Finished")
 }
 
-//
+// someKeyInjector carries "SomeKey" over from the caller's context into the detached context the
+// dispatched HandleFunc actually runs under.
+type someKeyInjector struct{}
+
+func (someKeyInjector) Inject(ctx context.Context, carrier async.Carrier) {
+	val, ok := ctx.Value("SomeKey").(string)
+	if !ok {
+		return
+	}
+
+	carrier.Set("SomeKey", val)
+}
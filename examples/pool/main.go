@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,7 +11,7 @@ import (
 
 func main() {
 	s := &service{}
-	pool := async.NewPool(s.DoWork)
+	pool := async.NewTypedPool(s.DoWork)
 	http.Handle("/do-work", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		i, err := strconv.Atoi(r.URL.Query().Get("i"))
 		if err != nil {
@@ -29,3 +30,10 @@ func main() {
 
 	http.ListenAndServe(":4684", nil)
 }
+
+type service struct{}
+
+func (s *service) DoWork(ctx context.Context, i int) error {
+	fmt.Println("working on", i)
+	return nil
+}
@@ -3,7 +3,6 @@ package main
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/OrenRosen/async"
 )
@@ -11,7 +10,7 @@ import (
 func main() {
 	// initialize the pool
 	// open 10 go routine, in each go routine a worker is listens on a channel for a received function
-	pool := async.NewPool()
+	pool := async.NewPool(context.Background())
 
 	// call `pool.RunAsync` with a context and a closure.
 	// this will add the passed function to the queue channel for be consumed by an available worker
@@ -20,7 +19,10 @@ func main() {
 		return nil
 	})
 
-	// for the example, sleeping in order to see the print from the async function
-	fmt.Println("going to sleep...")
-	time.Sleep(time.Second)
-}
\ No newline at end of file
+	// wait for the dispatched work to finish instead of guessing with a sleep
+	fmt.Println("waiting for work to finish...")
+	pool.Wait()
+
+	// Close stops the pool from accepting new work and releases its workers.
+	_ = pool.Close(context.Background())
+}
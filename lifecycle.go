@@ -0,0 +1,102 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// lifecycleState models the states a baseService moves through: New -> Started -> Stopping -> Stopped.
+type lifecycleState int32
+
+const (
+	stateNew lifecycleState = iota
+	stateStarted
+	stateStopping
+	stateStopped
+)
+
+// ErrNotRunning is reported when RunAsync is called on an Async or Pool that isn't currently running,
+// e.g. after Stop has already been called.
+var ErrNotRunning = errors.New("async: not running")
+
+// baseService is the lifecycle shared by Async and Pool: an atomic New -> Started -> Stopping -> Stopped
+// state machine, with a Stop that is idempotent and safe for concurrent callers.
+//
+// Accepting new work and draining it race unless they're synchronized against the same lock: a plain
+// IsRunning check followed by a separate wg.Add(1) lets Stop's wg.Wait() observe an empty WaitGroup and
+// return "drained" while that dispatch is still in flight, or panic with "WaitGroup misuse: Add called
+// concurrently with Wait". Acquire/Release close that gap by holding mu for the whole
+// check-and-increment, and having Stop take mu itself before it starts waiting, so Stop can never
+// observe "not running" while an Acquire call is mid-flight.
+type baseService struct {
+	mu       sync.RWMutex
+	state    int32
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newBaseService() *baseService {
+	return &baseService{}
+}
+
+// Start transitions the service from New to Started. Once the service has been stopped, Start returns
+// ErrNotRunning; calling it again while already started is a no-op.
+func (b *baseService) Start(_ context.Context) error {
+	if atomic.LoadInt32(&b.state) >= int32(stateStopping) {
+		return ErrNotRunning
+	}
+
+	atomic.CompareAndSwapInt32(&b.state, int32(stateNew), int32(stateStarted))
+	return nil
+}
+
+// IsRunning reports whether the service is currently in the Started state.
+func (b *baseService) IsRunning() bool {
+	return atomic.LoadInt32(&b.state) == int32(stateStarted)
+}
+
+// Acquire reports whether the service is still running and, if so, registers one unit of in-flight work
+// with Wait before returning. Callers that get true back must call Release exactly once, from wherever
+// the in-flight work actually finishes (which may be a different goroutine than the one that called
+// Acquire). Acquire and Stop are mutually exclusive, so Stop's Wait can never race a concurrent Acquire.
+func (b *baseService) Acquire() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if atomic.LoadInt32(&b.state) >= int32(stateStopping) {
+		return false
+	}
+
+	b.wg.Add(1)
+	return true
+}
+
+// Release marks one unit of work registered by Acquire as finished.
+func (b *baseService) Release() {
+	b.wg.Done()
+}
+
+// Wait blocks until every unit of work registered by Acquire has been Released.
+func (b *baseService) Wait() {
+	b.wg.Wait()
+}
+
+// Stop moves the service through Stopping to Stopped, invoking onStop exactly once. It is idempotent
+// and safe to call concurrently; onStop only ever runs for the first caller.
+func (b *baseService) Stop(onStop func()) {
+	b.stopOnce.Do(func() {
+		atomic.StoreInt32(&b.state, int32(stateStopping))
+
+		// Block until any Acquire that started before the state flip above has finished its
+		// wg.Add, so onStop's wg.Wait can't race it.
+		b.mu.Lock()
+		b.mu.Unlock()
+
+		if onStop != nil {
+			onStop()
+		}
+		atomic.StoreInt32(&b.state, int32(stateStopped))
+	})
+}
@@ -0,0 +1,134 @@
+package async_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async"
+)
+
+func Test_Pool_Close_DoubleStopIsNoop(t *testing.T) {
+	pool := async.NewPool(context.Background())
+
+	require.NoError(t, pool.Close(context.Background()))
+	require.NoError(t, pool.Close(context.Background()))
+	require.False(t, pool.IsRunning())
+}
+
+func Test_Pool_RunAsync_AfterClose(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	pool := async.NewPool(context.Background(), async.WithPoolErrorReporter(rep))
+
+	require.NoError(t, pool.Close(context.Background()))
+
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("expected reporter to be called with ErrNotRunning")
+	}
+	require.ErrorIs(t, rep.lastErr, async.ErrNotRunning)
+}
+
+func Test_Pool_RunAsync_PanicDoesNotCorruptState(t *testing.T) {
+	rep := &reporter{}
+	pool := async.NewPool(context.Background(), async.WithPoolErrorReporter(rep))
+
+	ch := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		defer close(ch)
+		panic("boom")
+	})
+
+	select {
+	case <-ch:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("timeout waiting for panicking handler")
+	}
+
+	require.True(t, pool.IsRunning())
+
+	ch2 := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		close(ch2)
+		return nil
+	})
+
+	select {
+	case <-ch2:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("pool stopped serving work after a panic")
+	}
+}
+
+func Test_Pool_Close_TimeoutDrainsAbandonedQueueEntries(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 10)}
+	pool := async.NewPool(
+		context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolNumberOfWorkers(1),
+	)
+
+	release := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		pool.RunAsync(context.Background(), func(ctx context.Context) error {
+			return nil
+		})
+	}
+	time.Sleep(time.Millisecond * 20)
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+	require.ErrorIs(t, pool.Close(closeCtx), context.DeadlineExceeded)
+
+	close(release)
+
+	waitDone := make(chan struct{})
+	go func() {
+		pool.Wait()
+		close(waitDone)
+	}()
+
+	select {
+	case <-waitDone:
+	case <-time.After(time.Second):
+		t.Fatal("Wait never returned after Close abandoned queued entries on timeout")
+	}
+}
+
+func Test_Async_Stop_DoubleStopIsNoop(t *testing.T) {
+	asyncer := async.New()
+
+	require.NoError(t, asyncer.Stop(context.Background()))
+	require.NoError(t, asyncer.Stop(context.Background()))
+	require.False(t, asyncer.IsRunning())
+}
+
+func Test_Async_RunAsync_AfterStop(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	asyncer := async.New(async.WithErrorReporter(rep))
+
+	require.NoError(t, asyncer.Stop(context.Background()))
+
+	asyncer.RunAsync(context.Background(), func(ctx context.Context) error {
+		return nil
+	})
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("expected reporter to be called with ErrNotRunning")
+	}
+	require.ErrorIs(t, rep.lastErr, async.ErrNotRunning)
+}
@@ -0,0 +1,29 @@
+package async
+
+import "time"
+
+// Metrics lets an Async or Pool report saturation and utilization so operators can see it approaching
+// its limits before requests start failing.
+type Metrics interface {
+	// ObserveEnqueueLatency records how long RunAsync spent waiting to hand work to a worker.
+	ObserveEnqueueLatency(d time.Duration)
+	// ObserveFuncDuration records how long a HandleFunc invocation took, and whether it returned an error.
+	ObserveFuncDuration(d time.Duration, err error)
+	// SetQueueDepth reports how many HandleFunc calls are currently queued in a Pool.
+	SetQueueDepth(n int)
+	// IncEnqueueTimeout is called whenever RunAsync times out waiting to hand off work.
+	IncEnqueueTimeout()
+	// IncPanic is called whenever a HandleFunc invocation panics.
+	IncPanic()
+	// SetActiveWorkers reports how many Pool workers are currently executing a HandleFunc.
+	SetActiveWorkers(n int)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveEnqueueLatency(d time.Duration)        {}
+func (noopMetrics) ObserveFuncDuration(d time.Duration, _ error) {}
+func (noopMetrics) SetQueueDepth(n int)                          {}
+func (noopMetrics) IncEnqueueTimeout()                           {}
+func (noopMetrics) IncPanic()                                    {}
+func (noopMetrics) SetActiveWorkers(n int)                       {}
@@ -0,0 +1,102 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async"
+)
+
+type fakeMetrics struct {
+	mu            sync.Mutex
+	funcDurations int
+	funcErrors    int
+	panics        int
+	queueDepths   []int
+	activeWorkers []int
+}
+
+func (m *fakeMetrics) ObserveEnqueueLatency(d time.Duration) {}
+
+func (m *fakeMetrics) ObserveFuncDuration(d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.funcDurations++
+	if err != nil {
+		m.funcErrors++
+	}
+}
+
+func (m *fakeMetrics) SetQueueDepth(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queueDepths = append(m.queueDepths, n)
+}
+
+func (m *fakeMetrics) IncEnqueueTimeout() {}
+
+func (m *fakeMetrics) IncPanic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.panics++
+}
+
+func (m *fakeMetrics) SetActiveWorkers(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.activeWorkers = append(m.activeWorkers, n)
+}
+
+func Test_Pool_Metrics_ObserveFuncDuration(t *testing.T) {
+	rep := &reporter{}
+	metrics := &fakeMetrics{}
+	pool := async.NewPool(context.Background(), async.WithPoolErrorReporter(rep), async.WithPoolMetrics(metrics))
+
+	ch := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		defer close(ch)
+		return errors.New("boom")
+	})
+
+	select {
+	case <-ch:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("timeout waiting for handler")
+	}
+
+	require.NoError(t, pool.Close(context.Background()))
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	require.Equal(t, 1, metrics.funcDurations)
+	require.Equal(t, 1, metrics.funcErrors)
+}
+
+func Test_Pool_Metrics_IncPanic(t *testing.T) {
+	rep := &reporter{}
+	metrics := &fakeMetrics{}
+	pool := async.NewPool(context.Background(), async.WithPoolErrorReporter(rep), async.WithPoolMetrics(metrics))
+
+	ch := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		defer close(ch)
+		panic("boom")
+	})
+
+	select {
+	case <-ch:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("timeout waiting for handler")
+	}
+
+	require.NoError(t, pool.Close(context.Background()))
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	require.Equal(t, 1, metrics.panics)
+}
@@ -0,0 +1,59 @@
+package async
+
+import (
+	"context"
+	"time"
+)
+
+type ctxKey int
+
+const (
+	ctxKeyWorkerID ctxKey = iota
+	ctxKeyQueueWait
+	ctxKeyAttempt
+)
+
+// WorkerID returns the id of the Pool worker handling the current HandleFunc invocation. It is only
+// set for work running through a Pool, not through an Async.
+func WorkerID(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(ctxKeyWorkerID).(int)
+	return id, ok
+}
+
+// QueueWaitTime returns how long the current HandleFunc waited in a Pool's queue before a worker
+// picked it up.
+func QueueWaitTime(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(ctxKeyQueueWait).(time.Duration)
+	return d, ok
+}
+
+// Attempt returns the 1-indexed attempt number of the current HandleFunc invocation. It is always set,
+// and only ever greater than 1 when a RetryPolicy is configured and earlier attempts failed.
+func Attempt(ctx context.Context) (int, bool) {
+	a, ok := ctx.Value(ctxKeyAttempt).(int)
+	return a, ok
+}
+
+func withWorkerID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, ctxKeyWorkerID, id)
+}
+
+func withQueueWait(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, ctxKeyQueueWait, d)
+}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, ctxKeyAttempt, attempt)
+}
+
+// Middleware wraps a HandleFunc, e.g. to add tracing or metrics around every invocation. Middlewares
+// run on every attempt when a RetryPolicy is configured. Attempt is always readable from ctx inside one;
+// WorkerID/QueueWaitTime are readable only for work run through a Pool.
+type Middleware func(HandleFunc) HandleFunc
+
+func applyMiddlewares(fn HandleFunc, middlewares []Middleware) HandleFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		fn = middlewares[i](fn)
+	}
+	return fn
+}
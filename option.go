@@ -12,6 +12,9 @@ type Config struct {
 	contextInjectors    []Injector
 	maxGoRoutines       uint
 	timeoutForGuard     time.Duration
+	retryPolicy         *RetryPolicy
+	middlewares         []Middleware
+	metrics             Metrics
 }
 
 func WithTimeoutForGuard(t time.Duration) AsyncOption {
@@ -47,23 +50,51 @@ func WithContextInjector(injector Injector) AsyncOption {
 	}
 }
 
+// WithRetryPolicy makes the Async retry a HandleFunc that returns an error, following policy's bounded
+// exponential backoff, before finally reporting a *RetryExhaustedError.
+func WithRetryPolicy(policy RetryPolicy) AsyncOption {
+	return func(conf *Config) {
+		conf.retryPolicy = &policy
+	}
+}
+
+// WithMiddleware wraps every HandleFunc passed to RunAsync with m, e.g. to add tracing or metrics.
+// Middlewares run in the order they were added, around whichever attempt a RetryPolicy is currently on.
+func WithMiddleware(m Middleware) AsyncOption {
+	return func(conf *Config) {
+		conf.middlewares = append(conf.middlewares, m)
+	}
+}
+
+// WithAsyncMetrics reports guard-wait latency, HandleFunc duration, and panics to m. See the
+// prommetrics subpackage for a ready-made Metrics backed by a prometheus.Registerer.
+func WithAsyncMetrics(m Metrics) AsyncOption {
+	return func(conf *Config) {
+		conf.metrics = m
+	}
+}
+
 // pool options
 
 type PoolOption func(*PoolConfig)
 
 type PoolConfig struct {
 	reporter               ErrorReporter
-	timeoutForFN           time.Duration
+	timeoutForGoroutine    time.Duration
 	timeoutForInsertToPool time.Duration
 	contextInjectors       []Injector
 	poolSize               uint
 	numberOfWorkers        int
+	retryPolicy            *RetryPolicy
+	middlewares            []Middleware
+	metrics                Metrics
+	scheduler              Scheduler
 }
 
 // WithPoolTimeoutForFN sets the timeout for running the consumer's function.
 func WithPoolTimeoutForFN(t time.Duration) PoolOption {
 	return func(conf *PoolConfig) {
-		conf.timeoutForFN = t
+		conf.timeoutForGoroutine = t
 	}
 }
 
@@ -100,3 +131,38 @@ func WithPoolContextInjector(injector Injector) PoolOption {
 		conf.contextInjectors = append(conf.contextInjectors, injector)
 	}
 }
+
+// WithPoolRetryPolicy makes workers retry a HandleFunc that returns an error, following policy's bounded
+// exponential backoff, before finally reporting a *RetryExhaustedError.
+func WithPoolRetryPolicy(policy RetryPolicy) PoolOption {
+	return func(conf *PoolConfig) {
+		conf.retryPolicy = &policy
+	}
+}
+
+// WithPoolMiddleware wraps every HandleFunc passed to RunAsync/Dispatch with m, e.g. to add tracing or
+// metrics. Middlewares run in the order they were added, around whichever attempt a RetryPolicy is
+// currently on, and can read the worker id and queue wait time via WorkerID/QueueWaitTime.
+func WithPoolMiddleware(m Middleware) PoolOption {
+	return func(conf *PoolConfig) {
+		conf.middlewares = append(conf.middlewares, m)
+	}
+}
+
+// WithPoolMetrics reports enqueue latency, queue depth, active worker count, HandleFunc duration, and
+// panics to m. See the prommetrics subpackage for a ready-made Metrics backed by a
+// prometheus.Registerer.
+func WithPoolMetrics(m Metrics) PoolOption {
+	return func(conf *PoolConfig) {
+		conf.metrics = m
+	}
+}
+
+// WithPoolScheduler replaces the pool's default FIFO Scheduler, e.g. with NewPriorityScheduler to
+// order work by RunAsyncWithPriority/Dispatch's EnqueueOptions.Priority and apply weighted round-robin
+// fairness across EnqueueOptions.TenantKey.
+func WithPoolScheduler(s Scheduler) PoolOption {
+	return func(conf *PoolConfig) {
+		conf.scheduler = s
+	}
+}
@@ -0,0 +1,143 @@
+// Package otelprop wires async.Async and async.Pool into an existing OpenTelemetry setup: a Carrier and
+// Injector that carry trace context across into a worker's detached context, and a tracing option that
+// wraps every HandleFunc invocation in its own span.
+package otelprop
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/OrenRosen/async"
+)
+
+// Carrier reads and writes through a context.Context using the same string key/value contract
+// async.Carrier relies on internally. It satisfies both async.Carrier and OpenTelemetry's
+// propagation.TextMapCarrier, so it can be handed directly to otel.GetTextMapPropagator().Inject/Extract.
+type Carrier struct {
+	ctx context.Context
+}
+
+// NewCarrier returns a Carrier backed by ctx.
+func NewCarrier(ctx context.Context) *Carrier {
+	return &Carrier{ctx: ctx}
+}
+
+// Context returns the context accumulated by Set calls.
+func (c *Carrier) Context() context.Context {
+	return c.ctx
+}
+
+// Get implements propagation.TextMapCarrier.
+func (c *Carrier) Get(key string) string {
+	v, _ := c.ctx.Value(key).(string)
+	return v
+}
+
+// Set implements both async.Carrier and propagation.TextMapCarrier.
+func (c *Carrier) Set(key, value string) {
+	c.ctx = context.WithValue(c.ctx, key, value)
+}
+
+// Keys implements propagation.TextMapCarrier, reporting the keys the globally configured propagator
+// reads and writes.
+func (c *Carrier) Keys() []string {
+	return otel.GetTextMapPropagator().Fields()
+}
+
+// Injector extracts the current trace.SpanContext from the source context and writes it, in the
+// propagator's encoding, into the worker's detached context. It does not make that span a parent of
+// anything: WithTracing/WithPoolTracing read it back and attach it to the worker's span as a link, so
+// the background work still shows up connected to the originating span in a trace.
+type Injector struct {
+	Propagator propagation.TextMapPropagator
+}
+
+// NewInjector returns an Injector using OpenTelemetry's globally configured TextMapPropagator.
+func NewInjector() *Injector {
+	return &Injector{Propagator: otel.GetTextMapPropagator()}
+}
+
+// Inject implements async.Injector.
+func (i *Injector) Inject(ctx context.Context, carrier async.Carrier) {
+	i.propagator().Inject(ctx, carrierAdapter{ctx: ctx, carrier: carrier})
+}
+
+func (i *Injector) propagator() propagation.TextMapPropagator {
+	if i.Propagator != nil {
+		return i.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// carrierAdapter lets an async.Carrier, which only exposes Set, stand in for a
+// propagation.TextMapCarrier during Inject, which never calls Get or Keys.
+type carrierAdapter struct {
+	ctx     context.Context
+	carrier async.Carrier
+}
+
+func (c carrierAdapter) Get(key string) string {
+	v, _ := c.ctx.Value(key).(string)
+	return v
+}
+
+func (c carrierAdapter) Set(key, value string) {
+	c.carrier.Set(key, value)
+}
+
+func (c carrierAdapter) Keys() []string {
+	return nil
+}
+
+// WithTracing wraps every HandleFunc run through an Async in its own span, named tracerName, with
+// attributes for the attempt number (see async.Attempt). Pair it with async.WithContextInjector(NewInjector())
+// so the span links back to the context the work was dispatched from.
+func WithTracing(tracerName string) async.AsyncOption {
+	return async.WithMiddleware(tracingMiddleware(tracerName))
+}
+
+// WithPoolTracing wraps every HandleFunc run through a Pool in its own span, named tracerName, with
+// attributes for worker id, queue wait time, and attempt number. Pair it with
+// async.WithPoolContextInjector(NewInjector()) so the span links back to the context the work was
+// dispatched from.
+func WithPoolTracing(tracerName string) async.PoolOption {
+	return async.WithPoolMiddleware(tracingMiddleware(tracerName))
+}
+
+func tracingMiddleware(tracerName string) async.Middleware {
+	tracer := otel.Tracer(tracerName)
+
+	return func(next async.HandleFunc) async.HandleFunc {
+		return func(ctx context.Context) error {
+			opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindInternal)}
+
+			extracted := otel.GetTextMapPropagator().Extract(context.Background(), NewCarrier(ctx))
+			if sc := trace.SpanContextFromContext(extracted); sc.IsValid() {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+			}
+
+			if id, ok := async.WorkerID(ctx); ok {
+				opts = append(opts, trace.WithAttributes(attribute.Int("async.worker_id", id)))
+			}
+			if wait, ok := async.QueueWaitTime(ctx); ok {
+				opts = append(opts, trace.WithAttributes(attribute.Int64("async.queue_wait_ms", wait.Milliseconds())))
+			}
+			if attempt, ok := async.Attempt(ctx); ok {
+				opts = append(opts, trace.WithAttributes(attribute.Int("async.attempt", attempt)))
+			}
+
+			spanCtx, span := tracer.Start(ctx, tracerName, opts...)
+			defer span.End()
+
+			if err := next(spanCtx); err != nil {
+				span.RecordError(err)
+				return err
+			}
+			return nil
+		}
+	}
+}
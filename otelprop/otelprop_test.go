@@ -0,0 +1,114 @@
+package otelprop_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/OrenRosen/async"
+	"github.com/OrenRosen/async/otelprop"
+)
+
+func Test_Carrier_SetGet(t *testing.T) {
+	carrier := otelprop.NewCarrier(context.Background())
+
+	carrier.Set("traceparent", "00-abc-def-01")
+	require.Equal(t, "00-abc-def-01", carrier.Get("traceparent"))
+	require.Empty(t, carrier.Get("missing"))
+}
+
+func Test_Injector_Inject_NoActiveSpanWritesNothing(t *testing.T) {
+	ctx := context.Background()
+	injector := otelprop.NewInjector()
+
+	got := make(map[string]string)
+	injector.Inject(ctx, carrierFunc(func(key, value string) {
+		got[key] = value
+	}))
+
+	// with no active span in ctx, the default propagator writes nothing.
+	require.Empty(t, got)
+}
+
+type carrierFunc func(key, value string)
+
+func (f carrierFunc) Set(key, value string) {
+	f(key, value)
+}
+
+// Test_WithPoolTracing_EmitsSpanLinkedToSource exercises the actual deliverable of WithPoolTracing: a
+// span per HandleFunc invocation, named after tracerName, linked back to the span the work was
+// dispatched from, and carrying the worker_id/queue_wait_ms/attempt attributes. It uses
+// tracetest.NewInMemoryExporter so the emitted spans can be asserted on directly instead of only
+// testing the low-level Carrier/Injector plumbing.
+func Test_WithPoolTracing_EmitsSpanLinkedToSource(t *testing.T) {
+	prevTP := otel.GetTracerProvider()
+	prevProp := otel.GetTextMapPropagator()
+	defer otel.SetTracerProvider(prevTP)
+	defer otel.SetTextMapPropagator(prevProp)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	const tracerName = "otelprop-test"
+	pool := async.NewPool(context.Background(),
+		async.WithPoolNumberOfWorkers(1),
+		async.WithPoolContextInjector(otelprop.NewInjector()),
+		otelprop.WithPoolTracing(tracerName),
+	)
+	defer pool.Close(context.Background())
+
+	sourceCtx, sourceSpan := tp.Tracer("source").Start(context.Background(), "source-span")
+	sourceSC := sourceSpan.SpanContext()
+	sourceSpan.End()
+
+	done := make(chan struct{})
+	pool.RunAsync(sourceCtx, func(ctx context.Context) error {
+		close(done)
+		return nil
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for handler")
+	}
+	// the span only ends once the handler has returned, so wait for the pool before reading spans.
+	pool.Wait()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+
+	var span tracetest.SpanStub
+	for _, s := range exporter.GetSpans() {
+		if s.Name == tracerName {
+			span = s
+		}
+	}
+	require.Equal(t, tracerName, span.Name)
+
+	require.Len(t, span.Links, 1)
+	require.Equal(t, sourceSC.TraceID(), span.Links[0].SpanContext.TraceID())
+	require.Equal(t, sourceSC.SpanID(), span.Links[0].SpanContext.SpanID())
+
+	attrs := attributeMap(span.Attributes)
+	require.Equal(t, int64(0), attrs["async.worker_id"].AsInt64())
+	require.GreaterOrEqual(t, attrs["async.queue_wait_ms"].AsInt64(), int64(0))
+	require.Equal(t, int64(1), attrs["async.attempt"].AsInt64())
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
@@ -3,130 +3,342 @@ package async
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	defaulttimeoutInsertToPool = time.Second * 5
-	
+
 	defaultNumWorkers = 10
 	defaultPoolSize   = 100
 )
 
+// ErrPoolClosed is reported to the ErrorReporter when RunAsync is called after Close has been invoked.
+//
+// Deprecated: check for ErrNotRunning instead.
+var ErrPoolClosed = ErrNotRunning
+
 type funcChannelData struct {
-	ctx context.Context
-	fn  HandleFunc
+	ctx        context.Context
+	fn         HandleFunc
+	enqueuedAt time.Time
+	opts       EnqueueOptions
 }
 
 // Pool is a generic type for handling asynchronous calls.
 //
 // It opens n workers that listen
 type Pool struct {
-	funcChannel         chan funcChannelData
+	scheduler           Scheduler
 	reporter            ErrorReporter
 	timeoutInsertToPool time.Duration
 	contextInjectors    []Injector
+	metrics             Metrics
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	svc    *baseService
 }
 
 type HandleFunc func(ctx context.Context) error
 
 // NewPool creates a new Pool instance. The method initializes n number of workers (10 is the default) that listen for a received function.
 //
-// When calling Pool.RunAsync with HandleFunc, it adds the function to a channel which consumed by the workers.
+// ctx is the pool's base context: it is propagated to every worker, so canceling it (or letting Close's
+// deadline expire) cancels whatever is currently in flight.
+//
+// When calling Pool.RunAsync with HandleFunc, it adds the function to the pool's Scheduler (a FIFO
+// channel by default, see WithPoolScheduler) which is consumed by the workers.
 //
 // Options:
-//	- WithTimeoutForGoRoutine: the max time to wait for fn to be finished.
-//	- WithErrorReporter: add a custom reporter that will be triggered in case of an error.
-//	- WithContextInjector
-//	- WithNumberOfWorkers: The amount of workers.
+//	- WithPoolTimeoutForFN: the max time to wait for fn to be finished.
+//	- WithPoolErrorReporter: add a custom reporter that will be triggered in case of an error.
+//	- WithPoolContextInjector
+//	- WithPoolNumberOfWorkers: The amount of workers.
 //	- WithPoolSize: The size of the pool. When calling Pool.Dispatch when the pool is fool, it will wait until the timeout had reached.
-// Note - can't close the pool.
-//
-// TODO - add Close functionality.
-func NewPool(options ...PoolOption) *Pool {
+//	- WithPoolRetryPolicy: retry a failed HandleFunc with exponential backoff before reporting it.
+//	- WithPoolMiddleware: wrap every HandleFunc invocation, e.g. with tracing.
+//	- WithPoolMetrics: report queue depth, active workers, and latencies.
+//	- WithPoolScheduler: replace the default FIFO scheduler, e.g. with NewPriorityScheduler.
+func NewPool(ctx context.Context, options ...PoolOption) *Pool {
 	conf := PoolConfig{
 		reporter:               noopReporter{},
 		timeoutForInsertToPool: defaulttimeoutInsertToPool,
 		timeoutForGoroutine:    defaultTimeoutForGoRoutine,
 		numberOfWorkers:        defaultNumWorkers,
 		poolSize:               defaultPoolSize,
+		metrics:                noopMetrics{},
 	}
-	
+
 	for _, op := range options {
 		op(&conf)
 	}
-	
+
+	scheduler := conf.scheduler
+	if scheduler == nil {
+		scheduler = newFIFOScheduler(conf.poolSize)
+	}
+
+	poolCtx, cancel := context.WithCancel(ctx)
+
 	p := &Pool{
-		funcChannel:         make(chan funcChannelData, conf.poolSize),
+		scheduler:           scheduler,
 		reporter:            conf.reporter,
 		timeoutInsertToPool: conf.timeoutForInsertToPool,
 		contextInjectors:    conf.contextInjectors,
+		metrics:             conf.metrics,
+		ctx:                 poolCtx,
+		cancel:              cancel,
+		svc:                 newBaseService(),
 	}
-	
+	_ = p.svc.Start(ctx)
+
+	var activeWorkers int32
 	for i := 0; i < conf.numberOfWorkers; i++ {
 		w := worker{
-			id:          i,
-			funcChannel: p.funcChannel,
-			reporter:    conf.reporter,
-			timeout:     conf.timeoutForGoroutine,
+			id:            i,
+			ctx:           poolCtx,
+			scheduler:     scheduler,
+			reporter:      conf.reporter,
+			timeout:       conf.timeoutForGoroutine,
+			svc:           p.svc,
+			retryPolicy:   conf.retryPolicy,
+			middlewares:   conf.middlewares,
+			metrics:       conf.metrics,
+			activeWorkers: &activeWorkers,
 		}
 		w.startReceivingData()
 	}
-	
+
+	go p.reportQueueDepth(poolCtx)
+
 	return p
 }
 
-// RunAsync adds the function into the channel which will be received by a worker.
+// reportQueueDepth publishes the scheduler's queue depth to Metrics.SetQueueDepth once a second until
+// ctx is done, so operators can see a pool approaching saturation before timeoutInsertToPool starts
+// firing.
+func (p *Pool) reportQueueDepth(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.metrics.SetQueueDepth(p.scheduler.Len())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunAsync adds the function into the scheduler which will be received by a worker.
+//
+// Once Close has been called, RunAsync stops accepting new work and reports ErrNotRunning instead.
+//
+// Deprecated: when every dispatched value shares one function signature, prefer NewTypedPool, which
+// dispatches a typed value instead of a pre-built HandleFunc closure. RunAsync remains the right choice
+// for heterogeneous work that doesn't fit a single typed handler.
 func (p *Pool) RunAsync(ctx context.Context, fn HandleFunc) {
+	p.dispatch(ctx, fn, EnqueueOptions{})
+}
+
+// RunAsyncWithPriority is like RunAsync, but lets a priority-aware Scheduler (see NewPriorityScheduler)
+// run fn ahead of work enqueued with a lower priority. It has no effect on the default FIFO scheduler.
+func (p *Pool) RunAsyncWithPriority(ctx context.Context, priority int, fn HandleFunc) {
+	p.dispatch(ctx, fn, EnqueueOptions{Priority: priority})
+}
+
+// Dispatch is RunAsync with full control over EnqueueOptions: priority and tenant fairness for a
+// priority-aware Scheduler, and a Deadline after which a still-queued fn is dropped and reported via
+// ErrDeadlineExceeded instead of run.
+//
+// Deprecated: when every dispatched value shares one function signature, prefer NewTypedPool and
+// (*TypedPool[T]).Dispatch, which accepts a typed value instead of a pre-built HandleFunc closure.
+func (p *Pool) Dispatch(ctx context.Context, fn HandleFunc, opts EnqueueOptions) {
+	p.dispatch(ctx, fn, opts)
+}
+
+func (p *Pool) dispatch(ctx context.Context, fn HandleFunc, opts EnqueueOptions) {
+	if !p.svc.Acquire() {
+		p.reporter.Error(ctx, ErrNotRunning)
+		return
+	}
+
 	data := funcChannelData{
-		ctx: p.asyncContext(ctx),
-		fn:  fn,
+		ctx:        p.asyncContext(ctx),
+		fn:         fn,
+		enqueuedAt: time.Now(),
+		opts:       opts,
 	}
-	
+
 	go func() {
-		select {
-		case p.funcChannel <- data:
-		case <-time.After(p.timeoutInsertToPool):
-			err := fmt.Errorf("pool.Dispatch channel is full, timeout waiting for dispatch")
+		start := time.Now()
+		enqueueCtx, cancel := context.WithTimeout(context.Background(), p.timeoutInsertToPool)
+		defer cancel()
+
+		if err := p.scheduler.Enqueue(enqueueCtx, data, opts); err != nil {
+			p.svc.Release()
+			p.metrics.IncEnqueueTimeout()
+			err := fmt.Errorf("pool.Dispatch channel is full, timeout waiting for dispatch: %w", err)
 			p.reporter.Error(ctx, err)
+			return
 		}
+
+		p.metrics.ObserveEnqueueLatency(time.Since(start))
 	}()
 }
 
+// Wait blocks until every dispatched HandleFunc accepted into the pool has finished running.
+func (p *Pool) Wait() {
+	p.svc.Wait()
+}
+
+// Close stops the pool from accepting new work and waits for already-dispatched work to drain, up to
+// ctx's deadline. If the deadline is reached first, the in-flight worker contexts are canceled, any
+// entries still sitting in the scheduler are abandoned (reported to the ErrorReporter and released
+// rather than left to leak Pool's accounting), and Close returns an error wrapping ctx.Err(). Close is idempotent and safe
+// for concurrent callers.
+func (p *Pool) Close(ctx context.Context) error {
+	var err error
+	p.svc.Stop(func() {
+		done := make(chan struct{})
+		go func() {
+			p.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			p.cancel()
+		case <-ctx.Done():
+			err = fmt.Errorf("async pool Close: %w", ctx.Err())
+			p.cancel()
+			p.drainScheduler(ctx.Err())
+		}
+	})
+
+	return err
+}
+
+// drainScheduler releases every entry still sitting in the scheduler once Close's deadline has passed
+// without the pool finishing its drain naturally, reporting each as abandoned. Once p.cancel has run,
+// every worker's Dequeue is already returning ctx.Err() and won't touch these entries itself, so without
+// this they'd sit Acquire()'d but never Release()'d, permanently corrupting p.svc's WaitGroup and hanging
+// every future Wait/Close on this Pool. drainCtx bounds the loop in case a worker is still mid-select and
+// briefly races us for the same entries.
+func (p *Pool) drainScheduler(cause error) {
+	drainCtx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	for p.scheduler.Len() > 0 {
+		data, err := p.scheduler.Dequeue(drainCtx)
+		if err != nil {
+			return
+		}
+
+		p.reporter.Error(data.ctx, fmt.Errorf("async pool Close: abandoning queued entry: %w", cause))
+		p.svc.Release()
+	}
+}
+
+// Stop is an alias for Close, matching the Start/Stop/Wait naming of the shared service lifecycle.
+func (p *Pool) Stop(ctx context.Context) error {
+	return p.Close(ctx)
+}
+
+// IsRunning reports whether the pool is still accepting new work.
+func (p *Pool) IsRunning() bool {
+	return p.svc.IsRunning()
+}
+
 type worker struct {
-	id          int
-	funcChannel chan funcChannelData
-	reporter    ErrorReporter
-	timeout     time.Duration
+	id            int
+	ctx           context.Context
+	scheduler     Scheduler
+	reporter      ErrorReporter
+	timeout       time.Duration
+	svc           *baseService
+	retryPolicy   *RetryPolicy
+	middlewares   []Middleware
+	metrics       Metrics
+	activeWorkers *int32
 }
 
 func (w *worker) startReceivingData() {
 	go func() {
-		for data := range w.funcChannel {
-			w.handleData(data.ctx, data.fn)
+		for {
+			data, err := w.scheduler.Dequeue(w.ctx)
+			if err != nil {
+				return
+			}
+
+			if !data.opts.Deadline.IsZero() && time.Now().After(data.opts.Deadline) {
+				w.reporter.Error(data.ctx, fmt.Errorf("async dispatch dropped: %w", ErrDeadlineExceeded))
+				w.svc.Release()
+				continue
+			}
+
+			w.handleData(data.ctx, data.fn, data.enqueuedAt)
+			w.svc.Release()
 		}
 	}()
 }
 
-func (w *worker) handleData(ctx context.Context, fn HandleFunc) {
+func (w *worker) handleData(ctx context.Context, fn HandleFunc, enqueuedAt time.Time) {
+	ctx = withWorkerID(ctx, w.id)
+	ctx = withQueueWait(ctx, time.Since(enqueuedAt))
+	fn = applyMiddlewares(fn, w.middlewares)
+
+	w.metrics.SetActiveWorkers(int(atomic.AddInt32(w.activeWorkers, 1)))
+	defer func() {
+		w.metrics.SetActiveWorkers(int(atomic.AddInt32(w.activeWorkers, -1)))
+	}()
+
+	if w.retryPolicy != nil {
+		if err := runWithRetry(ctx, *w.retryPolicy, w.timeout, w.metrics, fn); err != nil {
+			w.reporter.Error(ctx, fmt.Errorf("async handleData: %w", err))
+		}
+		return
+	}
+
 	ctx, cacnelFunc := context.WithTimeout(ctx, w.timeout)
 	defer cacnelFunc()
-	
-	defer recoverPanic(ctx, w.reporter)
-	
-	if err := fn(ctx); err != nil {
+	ctx = withAttempt(ctx, 1)
+
+	defer recoverPanic(ctx, w.reporter, w.metrics)
+
+	start := time.Now()
+	err := fn(ctx)
+	w.metrics.ObserveFuncDuration(time.Since(start), err)
+
+	if err != nil {
 		err = fmt.Errorf("async handleData: %w", err)
 		w.reporter.Error(ctx, err)
 	}
 }
 
+// Injector moves values from an in-flight context into a Carrier, so an async worker's detached context
+// can carry them even though it isn't a descendant of the original context.
+type Injector interface {
+	Inject(ctx context.Context, carrier Carrier)
+}
+
+// ctxCarrier adapts a context.Context to the Carrier interface so an Injector can attach values to it.
+type ctxCarrier struct {
+	ctx context.Context
+}
+
+func (c *ctxCarrier) Set(key, value string) {
+	c.ctx = context.WithValue(c.ctx, key, value)
+}
+
 func (p *Pool) asyncContext(ctx context.Context) context.Context {
-	newCtx := context.Background()
-	
-	carrier := ctxCarrier{newCtx}
+	carrier := ctxCarrier{p.ctx}
 	for _, inj := range p.contextInjectors {
 		inj.Inject(ctx, &carrier)
 	}
-	
+
 	return carrier.ctx
 }
@@ -0,0 +1,107 @@
+// Package prommetrics implements async.Metrics against a prometheus.Registerer, so pool saturation,
+// queue depth, and worker utilization can be observed before a Pool's timeoutForInsertToPool starts
+// rejecting work.
+package prommetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/OrenRosen/async"
+)
+
+// Metrics implements async.Metrics, labeling every series with the pool name passed to New.
+type Metrics struct {
+	enqueueLatency  prometheus.Histogram
+	funcDuration    *prometheus.HistogramVec
+	queueDepth      prometheus.Gauge
+	enqueueTimeouts prometheus.Counter
+	panics          prometheus.Counter
+	activeWorkers   prometheus.Gauge
+}
+
+// New creates and registers a Metrics for the given pool name, e.g. "order_pool".
+func New(reg prometheus.Registerer, poolName string) *Metrics {
+	labels := prometheus.Labels{"pool": poolName}
+
+	m := &Metrics{
+		enqueueLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   "async",
+			Name:        "enqueue_latency_seconds",
+			Help:        "Time spent handing a HandleFunc off to a worker (or guard, for Async).",
+			ConstLabels: labels,
+		}),
+		funcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   "async",
+			Name:        "func_duration_seconds",
+			Help:        "Duration of HandleFunc executions, labeled by outcome.",
+			ConstLabels: labels,
+		}, []string{"outcome"}),
+		queueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "async",
+			Name:        "queue_depth",
+			Help:        "Number of HandleFunc calls currently queued in the pool.",
+			ConstLabels: labels,
+		}),
+		enqueueTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "async",
+			Name:        "enqueue_timeouts_total",
+			Help:        "Number of times handing off a HandleFunc timed out.",
+			ConstLabels: labels,
+		}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   "async",
+			Name:        "panics_total",
+			Help:        "Number of HandleFunc invocations that panicked.",
+			ConstLabels: labels,
+		}),
+		activeWorkers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   "async",
+			Name:        "active_workers",
+			Help:        "Number of pool workers currently executing a HandleFunc.",
+			ConstLabels: labels,
+		}),
+	}
+
+	reg.MustRegister(m.enqueueLatency, m.funcDuration, m.queueDepth, m.enqueueTimeouts, m.panics, m.activeWorkers)
+
+	return m
+}
+
+// ObserveEnqueueLatency implements async.Metrics.
+func (m *Metrics) ObserveEnqueueLatency(d time.Duration) {
+	m.enqueueLatency.Observe(d.Seconds())
+}
+
+// ObserveFuncDuration implements async.Metrics.
+func (m *Metrics) ObserveFuncDuration(d time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+
+	m.funcDuration.WithLabelValues(outcome).Observe(d.Seconds())
+}
+
+// SetQueueDepth implements async.Metrics.
+func (m *Metrics) SetQueueDepth(n int) {
+	m.queueDepth.Set(float64(n))
+}
+
+// IncEnqueueTimeout implements async.Metrics.
+func (m *Metrics) IncEnqueueTimeout() {
+	m.enqueueTimeouts.Inc()
+}
+
+// IncPanic implements async.Metrics.
+func (m *Metrics) IncPanic() {
+	m.panics.Inc()
+}
+
+// SetActiveWorkers implements async.Metrics.
+func (m *Metrics) SetActiveWorkers(n int) {
+	m.activeWorkers.Set(float64(n))
+}
+
+var _ async.Metrics = (*Metrics)(nil)
@@ -0,0 +1,35 @@
+package prommetrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async/prommetrics"
+)
+
+func Test_New_RegistersAllSeriesExactlyOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	require.NotPanics(t, func() {
+		prommetrics.New(reg, "test_pool")
+	})
+}
+
+func Test_Metrics_MethodsDoNotPanic(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := prommetrics.New(reg, "test_pool")
+
+	require.NotPanics(t, func() {
+		m.IncEnqueueTimeout()
+		m.IncPanic()
+		m.SetQueueDepth(5)
+		m.SetActiveWorkers(3)
+		m.ObserveEnqueueLatency(time.Millisecond)
+		m.ObserveFuncDuration(time.Millisecond, errors.New("boom"))
+		m.ObserveFuncDuration(time.Millisecond, nil)
+	})
+}
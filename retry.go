@@ -0,0 +1,130 @@
+package async
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryableFunc classifies whether an error returned from a HandleFunc should be retried. When nil,
+// every non-nil error is treated as retryable.
+type RetryableFunc func(error) bool
+
+// RetryPolicy configures bounded exponential backoff retry of a failed HandleFunc.
+//
+// Between attempts the worker sleeps for min(MaxInterval, InitialInterval * Multiplier^attempt),
+// jittered by +/-RandomizationFactor.
+type RetryPolicy struct {
+	MaxAttempts         int
+	InitialInterval     time.Duration
+	MaxInterval         time.Duration
+	Multiplier          float64
+	RandomizationFactor float64
+	RetryableFunc       RetryableFunc
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	if p.RetryableFunc == nil {
+		return true
+	}
+
+	return p.RetryableFunc(err)
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	interval := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempt))
+	if max := float64(p.MaxInterval); p.MaxInterval > 0 && interval > max {
+		interval = max
+	}
+
+	if p.RandomizationFactor > 0 {
+		delta := p.RandomizationFactor * interval
+		interval = interval - delta + rand.Float64()*2*delta
+	}
+
+	if interval < 0 {
+		interval = 0
+	}
+
+	return time.Duration(interval)
+}
+
+// RetryExhaustedError is reported via the ErrorReporter once a HandleFunc has failed on every attempt
+// allowed by a RetryPolicy.
+type RetryExhaustedError struct {
+	attempts int
+	lastErr  error
+}
+
+func (e *RetryExhaustedError) Error() string {
+	return fmt.Sprintf("async: retry exhausted after %d attempt(s): %v", e.attempts, e.lastErr)
+}
+
+func (e *RetryExhaustedError) Unwrap() error {
+	return e.lastErr
+}
+
+// Attempts returns the number of times the HandleFunc was invoked.
+func (e *RetryExhaustedError) Attempts() int {
+	return e.attempts
+}
+
+// LastErr returns the error (or recovered panic) from the final attempt.
+func (e *RetryExhaustedError) LastErr() error {
+	return e.lastErr
+}
+
+// runWithRetry invokes fn under policy, re-deriving a fresh per-attempt timeout from ctx on every try.
+// A panic inside fn counts as a failed attempt instead of propagating. It returns nil on success, or a
+// *RetryExhaustedError once attempts are exhausted or ctx is done while waiting to retry.
+func runWithRetry(ctx context.Context, policy RetryPolicy, perAttemptTimeout time.Duration, metrics Metrics, fn HandleFunc) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		attemptCtx := withAttempt(ctx, attempt+1)
+
+		start := time.Now()
+		lastErr = callRecoveringPanic(attemptCtx, perAttemptTimeout, metrics, fn)
+		metrics.ObserveFuncDuration(time.Since(start), lastErr)
+
+		if lastErr == nil {
+			return nil
+		}
+
+		if attempt == maxAttempts-1 || !policy.isRetryable(lastErr) {
+			return &RetryExhaustedError{attempts: attempt + 1, lastErr: lastErr}
+		}
+
+		select {
+		case <-time.After(policy.backoff(attempt)):
+		case <-ctx.Done():
+			return &RetryExhaustedError{attempts: attempt + 1, lastErr: ctx.Err()}
+		}
+	}
+
+	return &RetryExhaustedError{attempts: maxAttempts, lastErr: lastErr}
+}
+
+func callRecoveringPanic(ctx context.Context, timeout time.Duration, metrics Metrics, fn HandleFunc) (err error) {
+	ctx, cancelFunc := context.WithTimeout(ctx, timeout)
+	defer cancelFunc()
+
+	defer func() {
+		if r := recover(); r != nil {
+			metrics.IncPanic()
+			if e, ok := r.(error); ok {
+				err = e
+				return
+			}
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return fn(ctx)
+}
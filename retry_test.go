@@ -0,0 +1,152 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async"
+)
+
+func Test_Pool_RetryPolicy_SucceedsBeforeExhaustion(t *testing.T) {
+	rep := &reporter{}
+	var calls int32
+
+	pool := async.NewPool(
+		context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolRetryPolicy(async.RetryPolicy{
+			MaxAttempts:     5,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+			Multiplier:      2,
+		}),
+	)
+
+	ch := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		n := atomic.AddInt32(&calls, 1)
+		if n < 3 {
+			return errors.New("not yet")
+		}
+		close(ch)
+		return nil
+	})
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("handler never succeeded")
+	}
+
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	require.False(t, rep.called)
+}
+
+func Test_Pool_RetryPolicy_ReportsRetryExhausted(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	var calls int32
+	wantErr := errors.New("always fails")
+
+	pool := async.NewPool(
+		context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolRetryPolicy(async.RetryPolicy{
+			MaxAttempts:     3,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+			Multiplier:      2,
+		}),
+	)
+
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return wantErr
+	})
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Second):
+		t.Fatal("reporter was never called")
+	}
+
+	require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+
+	var exhausted *async.RetryExhaustedError
+	require.True(t, errors.As(rep.lastErr, &exhausted))
+	require.Equal(t, 3, exhausted.Attempts())
+	require.ErrorIs(t, exhausted.LastErr(), wantErr)
+}
+
+func Test_Pool_RetryPolicy_NonRetryableStopsEarly(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	var calls int32
+	wantErr := errors.New("not retryable")
+
+	pool := async.NewPool(
+		context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolRetryPolicy(async.RetryPolicy{
+			MaxAttempts:     5,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+			Multiplier:      2,
+			RetryableFunc:   func(error) bool { return false },
+		}),
+	)
+
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return wantErr
+	})
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Second):
+		t.Fatal("reporter was never called")
+	}
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	var exhausted *async.RetryExhaustedError
+	require.True(t, errors.As(rep.lastErr, &exhausted))
+	require.Equal(t, 1, exhausted.Attempts())
+	require.ErrorIs(t, exhausted.LastErr(), wantErr)
+}
+
+func Test_Pool_RetryPolicy_PanicCountsAsFailedAttempt(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	var calls int32
+
+	pool := async.NewPool(
+		context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolRetryPolicy(async.RetryPolicy{
+			MaxAttempts:     2,
+			InitialInterval: time.Millisecond,
+			MaxInterval:     time.Millisecond * 10,
+			Multiplier:      2,
+		}),
+	)
+
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		panic("boom")
+	})
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Second):
+		t.Fatal("reporter was never called")
+	}
+
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+	var exhausted *async.RetryExhaustedError
+	require.True(t, errors.As(rep.lastErr, &exhausted))
+	require.Equal(t, 2, exhausted.Attempts())
+}
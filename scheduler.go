@@ -0,0 +1,253 @@
+package async
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrDeadlineExceeded is reported via the ErrorReporter when a Pool worker drops an entry whose
+// EnqueueOptions.Deadline passed while it was still queued.
+var ErrDeadlineExceeded = errors.New("async: entry deadline exceeded while queued")
+
+// EnqueueOptions customizes how an entry dispatched to a Pool is scheduled.
+type EnqueueOptions struct {
+	// Priority orders entries ahead of lower-priority ones within the same TenantKey. Higher runs
+	// first. Ignored by the default FIFO scheduler.
+	Priority int
+
+	// TenantKey groups entries for weighted round-robin fairness, so one tenant dispatching a lot of
+	// work can't starve the others. Entries with no TenantKey share a single, unweighted tenant.
+	TenantKey string
+
+	// Deadline, if non-zero, causes the entry to be dropped instead of run if it is still queued once
+	// the deadline passes. A dropped entry is reported via ErrDeadlineExceeded instead of being handed
+	// to a worker.
+	Deadline time.Time
+}
+
+// Scheduler decides the order queued work is handed to Pool workers. The default, returned by
+// newFIFOScheduler, preserves the Pool's original first-in-first-out channel behavior. NewPriorityScheduler
+// additionally orders by EnqueueOptions.Priority and applies weighted round-robin across TenantKey.
+type Scheduler interface {
+	// Enqueue adds data to the schedule, blocking until there's room or ctx is done.
+	Enqueue(ctx context.Context, data funcChannelData, opts EnqueueOptions) error
+
+	// Dequeue returns the next entry to run, blocking until one is available or ctx is done. An entry
+	// whose Deadline has already passed may still be returned; the caller (Pool's worker) is the single
+	// place responsible for dropping and reporting it instead of running it.
+	Dequeue(ctx context.Context) (funcChannelData, error)
+
+	// Len reports how many entries are currently queued, for queue depth metrics.
+	Len() int
+}
+
+// fifoScheduler is the default Scheduler: a single buffered channel, preserving Pool's original
+// behavior. It ignores EnqueueOptions entirely.
+type fifoScheduler struct {
+	ch chan funcChannelData
+}
+
+func newFIFOScheduler(size uint) *fifoScheduler {
+	return &fifoScheduler{ch: make(chan funcChannelData, size)}
+}
+
+func (s *fifoScheduler) Enqueue(ctx context.Context, data funcChannelData, _ EnqueueOptions) error {
+	select {
+	case s.ch <- data:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *fifoScheduler) Dequeue(ctx context.Context) (funcChannelData, error) {
+	select {
+	case data := <-s.ch:
+		return data, nil
+	case <-ctx.Done():
+		return funcChannelData{}, ctx.Err()
+	}
+}
+
+func (s *fifoScheduler) Len() int {
+	return len(s.ch)
+}
+
+// tenantQueue is a single tenant's pending entries, ordered by Priority then insertion order.
+type tenantQueue struct {
+	entries []*schedulerEntry
+	credit  int
+}
+
+type schedulerEntry struct {
+	data funcChannelData
+	opts EnqueueOptions
+	seq  int64
+}
+
+func (q *tenantQueue) Len() int { return len(q.entries) }
+
+func (q *tenantQueue) Less(i, j int) bool {
+	if q.entries[i].opts.Priority != q.entries[j].opts.Priority {
+		return q.entries[i].opts.Priority > q.entries[j].opts.Priority
+	}
+	return q.entries[i].seq < q.entries[j].seq
+}
+
+func (q *tenantQueue) Swap(i, j int) { q.entries[i], q.entries[j] = q.entries[j], q.entries[i] }
+
+func (q *tenantQueue) Push(x interface{}) { q.entries = append(q.entries, x.(*schedulerEntry)) }
+
+func (q *tenantQueue) Pop() interface{} {
+	old := q.entries
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	q.entries = old[:n-1]
+	return e
+}
+
+// PriorityScheduler is a Scheduler that runs higher-priority entries first (set via
+// Pool.RunAsyncWithPriority or Pool.Dispatch's EnqueueOptions.Priority), applying weighted
+// round-robin across EnqueueOptions.TenantKey so one noisy tenant can't starve the others.
+type PriorityScheduler struct {
+	weights map[string]int
+	sem     chan struct{}
+
+	mu       sync.Mutex
+	notEmpty chan struct{}
+	tenants  map[string]*tenantQueue
+	order    []string
+	cursor   int
+	seq      int64
+}
+
+// NewPriorityScheduler returns a PriorityScheduler holding at most capacity entries across all tenants;
+// once full, Enqueue blocks until an entry is dequeued or ctx is done, same as the default FIFO
+// scheduler. capacity <= 0 defaults to defaultPoolSize. weights sets a tenant key's round-robin share
+// relative to the others; a tenant key with no entry in weights defaults to 1.
+func NewPriorityScheduler(weights map[string]int, capacity int) *PriorityScheduler {
+	if capacity <= 0 {
+		capacity = defaultPoolSize
+	}
+
+	return &PriorityScheduler{
+		weights:  weights,
+		sem:      make(chan struct{}, capacity),
+		notEmpty: make(chan struct{}, 1),
+		tenants:  make(map[string]*tenantQueue),
+	}
+}
+
+func (s *PriorityScheduler) Enqueue(ctx context.Context, data funcChannelData, opts EnqueueOptions) error {
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	tq, ok := s.tenants[opts.TenantKey]
+	if !ok {
+		tq = &tenantQueue{}
+		s.tenants[opts.TenantKey] = tq
+		s.order = append(s.order, opts.TenantKey)
+	}
+
+	s.seq++
+	heap.Push(tq, &schedulerEntry{data: data, opts: opts, seq: s.seq})
+	s.mu.Unlock()
+
+	select {
+	case s.notEmpty <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (s *PriorityScheduler) Dequeue(ctx context.Context) (funcChannelData, error) {
+	for {
+		if entry, ok := s.popNext(); ok {
+			return entry.data, nil
+		}
+
+		select {
+		case <-s.notEmpty:
+		case <-ctx.Done():
+			return funcChannelData{}, ctx.Err()
+		}
+	}
+}
+
+// popNext returns the next entry in round-robin tenant order. It does not filter by deadline: the
+// generic deadline check in worker.startReceivingData is the single place that drops and reports an
+// expired entry, so that behavior is uniform across every Scheduler implementation rather than
+// duplicated (and double-accounted against Pool's in-flight count) here.
+func (s *PriorityScheduler) popNext() (*schedulerEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.order) > 0 {
+		if s.cursor >= len(s.order) {
+			s.cursor = 0
+		}
+		tenant := s.order[s.cursor]
+		tq := s.tenants[tenant]
+
+		if tq.Len() == 0 {
+			s.removeTenantLocked(tenant)
+			continue
+		}
+
+		entry := tq.entries[0]
+		heap.Pop(tq)
+		<-s.sem
+		s.advanceCursorLocked(tenant, tq)
+		return entry, true
+	}
+
+	return nil, false
+}
+
+func (s *PriorityScheduler) advanceCursorLocked(tenant string, tq *tenantQueue) {
+	tq.credit++
+	if tq.credit >= s.weightOf(tenant) {
+		tq.credit = 0
+		s.cursor++
+	}
+}
+
+func (s *PriorityScheduler) weightOf(tenant string) int {
+	if w, ok := s.weights[tenant]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+func (s *PriorityScheduler) removeTenantLocked(tenant string) {
+	for i, t := range s.order {
+		if t == tenant {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	delete(s.tenants, tenant)
+	if s.cursor >= len(s.order) {
+		s.cursor = 0
+	}
+}
+
+func (s *PriorityScheduler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, tq := range s.tenants {
+		n += tq.Len()
+	}
+	return n
+}
@@ -0,0 +1,126 @@
+package async
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Test_PriorityScheduler_EnqueueBlocksWhenFull confirms PriorityScheduler honors the Scheduler
+// interface's documented "blocking until there's room or ctx is done" contract, rather than growing the
+// queue without bound under sustained overload.
+func Test_PriorityScheduler_EnqueueBlocksWhenFull(t *testing.T) {
+	ctx := context.Background()
+	scheduler := NewPriorityScheduler(nil, 1)
+
+	if err := scheduler.Enqueue(ctx, funcChannelData{}, EnqueueOptions{}); err != nil {
+		t.Fatalf("first Enqueue should have room: %v", err)
+	}
+
+	blockedCtx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	if err := scheduler.Enqueue(blockedCtx, funcChannelData{}, EnqueueOptions{}); err == nil {
+		t.Fatal("expected Enqueue to block until ctx is done while the scheduler is full")
+	}
+
+	if _, err := scheduler.Dequeue(ctx); err != nil {
+		t.Fatalf("Dequeue: %v", err)
+	}
+
+	unblockedCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := scheduler.Enqueue(unblockedCtx, funcChannelData{}, EnqueueOptions{}); err != nil {
+		t.Fatalf("Enqueue should have room after a Dequeue freed a slot: %v", err)
+	}
+}
+
+// Test_PriorityScheduler_WeightedRoundRobin_PreventsStarvation confirms the weighted round-robin across
+// TenantKey (the headline feature of PriorityScheduler) actually gives a quiet tenant a turn at its
+// weighted share instead of starving it behind a noisy one. noisy enqueues first, so it also opens the
+// round-robin order; at weight 3 against quiet's weight 1, popNext should interleave exactly 3 noisy
+// entries for every 1 quiet entry.
+func Test_PriorityScheduler_WeightedRoundRobin_PreventsStarvation(t *testing.T) {
+	const (
+		noisyEntries = 9
+		quietEntries = 3
+	)
+
+	ctx := context.Background()
+	scheduler := NewPriorityScheduler(map[string]int{"noisy": 3, "quiet": 1}, 0)
+
+	for i := 0; i < noisyEntries; i++ {
+		opts := EnqueueOptions{TenantKey: "noisy"}
+		if err := scheduler.Enqueue(ctx, funcChannelData{opts: opts}, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < quietEntries; i++ {
+		opts := EnqueueOptions{TenantKey: "quiet"}
+		if err := scheduler.Enqueue(ctx, funcChannelData{opts: opts}, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{
+		"noisy", "noisy", "noisy", "quiet",
+		"noisy", "noisy", "noisy", "quiet",
+		"noisy", "noisy", "noisy", "quiet",
+	}
+
+	var got []string
+	for i := 0; i < noisyEntries+quietEntries; i++ {
+		data, err := scheduler.Dequeue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, data.opts.TenantKey)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("entry %d: got %q, want %q (full order: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+// Benchmark_PriorityScheduler_Ordering enqueues 10k entries across 5 priority levels and confirms the
+// PriorityScheduler always hands them back in non-increasing priority order within a tenant.
+func Benchmark_PriorityScheduler_Ordering(b *testing.B) {
+	const (
+		entries    = 10000
+		priorities = 5
+	)
+
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		scheduler := NewPriorityScheduler(nil, entries)
+
+		for j := 0; j < entries; j++ {
+			opts := EnqueueOptions{Priority: j % priorities}
+			if err := scheduler.Enqueue(ctx, funcChannelData{opts: opts}, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		last := priorities
+		for j := 0; j < entries; j++ {
+			data, err := scheduler.Dequeue(ctx)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			if data.opts.Priority > last {
+				b.Fatalf("ordering violated: got priority %d after %d", data.opts.Priority, last)
+			}
+			last = data.opts.Priority
+		}
+
+		if scheduler.Len() != 0 {
+			b.Fatalf("expected scheduler to be drained, got %d left", scheduler.Len())
+		}
+	}
+}
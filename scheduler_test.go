@@ -0,0 +1,109 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async"
+)
+
+func Test_PriorityScheduler_OrdersByPriority(t *testing.T) {
+	rep := &reporter{}
+	scheduler := async.NewPriorityScheduler(nil, 0)
+	pool := async.NewPool(context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolNumberOfWorkers(1),
+		async.WithPoolScheduler(scheduler),
+	)
+	defer pool.Close(context.Background())
+
+	// block the lone worker so every RunAsyncWithPriority call below queues up first.
+	release := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	time.Sleep(time.Millisecond * 20)
+
+	var mu sync.Mutex
+	var order []int
+	done := make(chan struct{}, 3)
+	record := func(priority int) async.HandleFunc {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+			done <- struct{}{}
+			return nil
+		}
+	}
+
+	pool.RunAsyncWithPriority(context.Background(), 1, record(1))
+	pool.RunAsyncWithPriority(context.Background(), 5, record(5))
+	pool.RunAsyncWithPriority(context.Background(), 3, record(3))
+	time.Sleep(time.Millisecond * 20)
+
+	close(release)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for handlers")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []int{5, 3, 1}, order)
+}
+
+func Test_PriorityScheduler_DropsExpiredEntries(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 2)}
+	scheduler := async.NewPriorityScheduler(nil, 0)
+	pool := async.NewPool(context.Background(),
+		async.WithPoolErrorReporter(rep),
+		async.WithPoolNumberOfWorkers(1),
+		async.WithPoolScheduler(scheduler),
+	)
+	defer pool.Close(context.Background())
+
+	release := make(chan struct{})
+	pool.RunAsync(context.Background(), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	time.Sleep(time.Millisecond * 20)
+
+	ran := make(chan struct{}, 1)
+	pool.Dispatch(context.Background(), func(ctx context.Context) error {
+		t.Fatal("expired entry must not run")
+		return nil
+	}, async.EnqueueOptions{Deadline: time.Now().Add(-time.Millisecond)})
+
+	pool.Dispatch(context.Background(), func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}, async.EnqueueOptions{Deadline: time.Now().Add(time.Minute)})
+
+	close(release)
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for live entry to run")
+	}
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for ErrDeadlineExceeded report")
+	}
+
+	require.True(t, errors.Is(rep.lastErr, async.ErrDeadlineExceeded))
+}
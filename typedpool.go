@@ -0,0 +1,52 @@
+package async
+
+import "context"
+
+// TypedPool is a type-safe wrapper around Pool for callers who only ever dispatch one value type T. It
+// reuses a Pool's workers, scheduler, retry policy, middlewares, and metrics unchanged, so Dispatch is
+// just RunAsync with fn(ctx, v) pre-bound instead of boxed into an interface{} or a closure the caller
+// has to build by hand.
+type TypedPool[T any] struct {
+	pool *Pool
+	fn   func(context.Context, T) error
+}
+
+// NewTypedPool creates a TypedPool that hands every dispatched value of type T to fn. options configure
+// the underlying Pool exactly as they would for NewPool (retry policy, middleware, metrics, scheduler,
+// number of workers, and so on).
+func NewTypedPool[T any](fn func(context.Context, T) error, options ...PoolOption) *TypedPool[T] {
+	return &TypedPool[T]{
+		pool: NewPool(context.Background(), options...),
+		fn:   fn,
+	}
+}
+
+// Dispatch hands v to the pool, to be passed to fn by an available worker.
+//
+// Once Close has been called, Dispatch stops accepting new work and reports ErrNotRunning instead.
+func (tp *TypedPool[T]) Dispatch(ctx context.Context, v T) {
+	tp.pool.RunAsync(ctx, func(ctx context.Context) error {
+		return tp.fn(ctx, v)
+	})
+}
+
+// Wait blocks until every dispatched value accepted into the pool has finished running.
+func (tp *TypedPool[T]) Wait() {
+	tp.pool.Wait()
+}
+
+// Close stops the pool from accepting new work and waits for already-dispatched work to drain, up to
+// ctx's deadline. See Pool.Close for full semantics.
+func (tp *TypedPool[T]) Close(ctx context.Context) error {
+	return tp.pool.Close(ctx)
+}
+
+// Stop is an alias for Close, matching the Start/Stop/Wait naming of the shared service lifecycle.
+func (tp *TypedPool[T]) Stop(ctx context.Context) error {
+	return tp.pool.Stop(ctx)
+}
+
+// IsRunning reports whether the pool is still accepting new work.
+func (tp *TypedPool[T]) IsRunning() bool {
+	return tp.pool.IsRunning()
+}
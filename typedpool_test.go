@@ -0,0 +1,43 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/OrenRosen/async"
+)
+
+func Test_TypedPool_Dispatch_ReportsHandlerError(t *testing.T) {
+	rep := &reporter{errorCh: make(chan struct{}, 1)}
+	pool := async.NewTypedPool(func(ctx context.Context, n int) error {
+		return errors.New("boom")
+	}, async.WithPoolErrorReporter(rep))
+	defer pool.Close(context.Background())
+
+	pool.Dispatch(context.Background(), 7)
+
+	select {
+	case <-rep.errorCh:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("timeout waiting for error report")
+	}
+
+	require.True(t, rep.called)
+}
+
+func Test_TypedPool_Dispatch_AfterClose(t *testing.T) {
+	rep := &reporter{}
+	pool := async.NewTypedPool(func(ctx context.Context, n int) error {
+		return nil
+	}, async.WithPoolErrorReporter(rep))
+
+	require.NoError(t, pool.Close(context.Background()))
+
+	pool.Dispatch(context.Background(), 1)
+
+	require.True(t, errors.Is(rep.lastErr, async.ErrNotRunning))
+}